@@ -0,0 +1,55 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package id3
+
+import (
+	"io"
+	"os"
+)
+
+// FSFile is the handle returned by an FS. It is satisfied by *os.File and
+// by the in-memory file returned by NewMemFS.
+type FSFile interface {
+	io.Reader
+	io.ReaderAt
+	io.Writer
+	io.Seeker
+	io.Closer
+	Truncate(size int64) error
+}
+
+// FS abstracts the filesystem operations Open needs, in the style of
+// afero.Fs, so that tags can be read from and written to stores other
+// than the local disk: in-memory buffers in tests, or blobs streamed from
+// object storage in a server.
+type FS interface {
+	Open(name string) (FSFile, error)
+	Create(name string) (FSFile, error)
+	OpenFile(name string, flag int, perm os.FileMode) (FSFile, error)
+	Stat(name string) (os.FileInfo, error)
+}
+
+// osFS implements FS on top of the local filesystem.
+type osFS struct{}
+
+func (osFS) Open(name string) (FSFile, error) {
+	return os.Open(name)
+}
+
+func (osFS) Create(name string) (FSFile, error) {
+	return os.Create(name)
+}
+
+func (osFS) OpenFile(name string, flag int, perm os.FileMode) (FSFile, error) {
+	return os.OpenFile(name, flag, perm)
+}
+
+func (osFS) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+// DefaultFS is the FS used by Open. It is a package variable so tests can
+// swap in a memFS wholesale if needed.
+var DefaultFS FS = osFS{}
@@ -0,0 +1,94 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package id3
+
+import (
+	"bytes"
+	"testing"
+
+	v2 "github.com/jcs/id3-go/v2"
+)
+
+func TestOpenFS(t *testing.T) {
+	fs := NewMemFS()
+
+	tag := v2.NewTag(3)
+	tag.SetArtist("Memory")
+	data := append(tag.Bytes(), []byte("not-really-audio")...)
+
+	w, err := fs.Create("mem.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := OpenFS(fs, "mem.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s := file.Artist(); s != "Memory" {
+		t.Errorf("OpenFS: incorrect artist, %v", s)
+	}
+
+	file.SetTitle("In Memory")
+	if err := file.Close(); err != nil {
+		t.Errorf("OpenFS: unable to close file")
+	}
+
+	reread, err := OpenFS(fs, "mem.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s := reread.Title(); s != "In Memory" {
+		t.Errorf("OpenFS: title not persisted, %v", s)
+	}
+}
+
+func TestOpenReaderWriteTo(t *testing.T) {
+	fs := NewMemFS()
+
+	tag := v2.NewTag(3)
+	tag.SetArtist("Stream")
+	data := append(tag.Bytes(), []byte("not-really-audio")...)
+
+	file, err := OpenReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s := file.Artist(); s != "Stream" {
+		t.Errorf("OpenReader: incorrect artist, %v", s)
+	}
+
+	file.SetTitle("Streamed")
+
+	out, err := fs.Create("out.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteTo(out, file); err != nil {
+		t.Fatal(err)
+	}
+	if err := out.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reread, err := OpenFS(fs, "out.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s := reread.Title(); s != "Streamed" {
+		t.Errorf("WriteTo: title not persisted, %v", s)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package id3
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// FuzzOpen feeds arbitrary bytes through OpenReader to make sure
+// malformed ID3v1/ID3v2 data never panics or hangs the parser. OpenReader
+// works entirely in memory, unlike Open, so the fuzz loop doesn't pay for
+// a temp file on every execution.
+func FuzzOpen(f *testing.F) {
+	seeds, _ := filepath.Glob("testdata/*")
+	for _, name := range seeds {
+		if data, err := ioutil.ReadFile(name); err == nil {
+			f.Add(data)
+		}
+	}
+	f.Add([]byte("ID3\x03\x00\x00\x00\x00\x00\x00"))
+	f.Add([]byte("TAG"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		file, err := OpenReader(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+
+		// Exercising the Tagger surface must never panic, regardless of
+		// what garbage ended up in the frames.
+		_ = file.Title()
+		_ = file.Artist()
+		_ = file.Album()
+		_ = file.Year()
+		_ = file.Genre()
+		_ = file.Comments()
+	})
+}
@@ -0,0 +1,447 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package id3 provides read/write access to ID3v1 and ID3v2 tags on MP3
+// files, preferring ID3v2 data where both are present.
+package id3
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+
+	v1 "github.com/jcs/id3-go/v1"
+	v2 "github.com/jcs/id3-go/v2"
+)
+
+// Tagger is the common, read/write metadata surface shared by the
+// underlying v1 and v2 tag implementations.
+type Tagger interface {
+	Title() string
+	SetTitle(string)
+	Artist() string
+	SetArtist(string)
+	Album() string
+	SetAlbum(string)
+	Year() string
+	SetYear(string)
+	Genre() string
+	SetGenre(string)
+	Comments() string
+	SetComments(string)
+
+	Size() int
+	Bytes() []byte
+}
+
+// File represents an MP3 file and the ID3 tags found within it. When both
+// an ID3v1 and an ID3v2 tag are present, the Tagger methods (Title,
+// Artist, ...) prefer the v2 value and fall back to v1; setters write
+// through to both.
+type File struct {
+	Tagger
+
+	// V1 is the trailing ID3v1/ID3v1.1 tag, or nil if the file has none.
+	// It is a *v1.V11Tag (which embeds V1Tag) even for a plain ID3v1 tag,
+	// so that an ID3v1.1 Track number surviving a read always survives
+	// the following write too.
+	V1 *v1.V11Tag
+	// V2 is the leading ID3v2 tag. It is never nil: Open creates an empty
+	// one if the file doesn't already have one.
+	V2 *v2.Tag
+
+	// ContainsV1 and ContainsV2 report whether the corresponding tag was
+	// actually present on disk when the file was opened.
+	ContainsV1 bool
+	ContainsV2 bool
+
+	// originalSize is the number of bytes the v2 tag occupied on disk (or
+	// would occupy, for a freshly created one) when the file was opened.
+	originalSize int
+	v1Size       int
+	size         int64
+	dirty        bool
+
+	// stream is the open handle backing Open/OpenFS files; it is nil for
+	// files parsed via OpenReader, which carry their audio payload in
+	// audio instead and rely on WriteTo to be serialized.
+	stream FSFile
+	audio  []byte
+}
+
+// Open opens the named file on the local filesystem and parses any ID3v1
+// and ID3v2 tags present. It is equivalent to OpenFS(DefaultFS, name).
+func Open(name string) (*File, error) {
+	return OpenFS(DefaultFS, name)
+}
+
+// OpenFS opens name through fs and parses any ID3v1 and ID3v2 tags
+// present. If no ID3v2 tag is found, an empty one is created so that
+// Tagger methods and Close always have somewhere to write new metadata.
+func OpenFS(fs FS, name string) (*File, error) {
+	stream, err := fs.OpenFile(name, os.O_RDWR, 0666)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := fs.Stat(name)
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+
+	f, err := parseFile(stream, fi.Size())
+	if err != nil {
+		stream.Close()
+		return nil, err
+	}
+
+	f.stream = stream
+
+	return f, nil
+}
+
+// OpenReader parses any ID3v1 and ID3v2 tags present in r, buffering the
+// audio payload in memory. Unlike Open/OpenFS, the returned File is not
+// bound to a writable sink: Close is a no-op, and edits must be
+// serialized explicitly with WriteTo.
+func OpenReader(r io.ReadSeeker) (*File, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	all, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := parseFile(bytes.NewReader(all), int64(len(all)))
+	if err != nil {
+		return nil, err
+	}
+
+	audioEnd := int64(len(all))
+	if f.ContainsV1 {
+		audioEnd -= int64(f.v1Size)
+	}
+	f.audio = all[f.originalSize:audioEnd]
+
+	return f, nil
+}
+
+// WriteTo serializes f's current tags and audio payload to w: the v2 tag,
+// then the audio, then the v1 tag if present.
+func WriteTo(w io.WriteSeeker, f *File) error {
+	audio, err := f.audioBytes()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(f.V2.Bytes()); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(audio); err != nil {
+		return err
+	}
+
+	if f.V1 != nil {
+		if _, err := w.Write(f.V1.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseFile parses the v2 and v1 tags out of a size-byte stream, without
+// binding the result to any particular writable sink.
+func parseFile(r io.ReaderAt, size int64) (*File, error) {
+	tag, err := v2.ParseTag(io.NewSectionReader(r, 0, size))
+	if err != nil {
+		return nil, err
+	}
+
+	containsV2 := tag != nil
+	originalSize := 0
+	if containsV2 {
+		originalSize = tag.OnDiskSize()
+	} else {
+		tag = v2.NewTag(v2.LatestVersion)
+	}
+
+	v1Tag, err := v1.ReadV11(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	v1Size := 0
+	if v1Tag != nil {
+		v1Size = v1Tag.Size()
+	}
+
+	return &File{
+		Tagger:       tag,
+		V1:           v1Tag,
+		V2:           tag,
+		ContainsV1:   v1Tag != nil,
+		ContainsV2:   containsV2,
+		originalSize: originalSize,
+		v1Size:       v1Size,
+		size:         size,
+	}, nil
+}
+
+// Frame returns the first ID3v2 frame with the given id, or nil.
+func (f *File) Frame(id string) v2.Framer {
+	return f.V2.Frame(id)
+}
+
+// Frames returns every ID3v2 frame with the given id.
+func (f *File) Frames(id string) []v2.Framer {
+	return f.V2.Frames(id)
+}
+
+// AddFrames adds one or more ID3v2 frames to the file's v2 tag.
+func (f *File) AddFrames(frames ...v2.Framer) {
+	f.dirty = true
+	f.V2.AddFrames(frames...)
+}
+
+// DeleteFrames removes and returns every ID3v2 frame with the given id.
+func (f *File) DeleteFrames(id string) []v2.Framer {
+	f.dirty = true
+	return f.V2.DeleteFrames(id)
+}
+
+// Size returns the size in bytes of the file's serialized ID3v2 tag.
+func (f *File) Size() int {
+	return f.V2.Size()
+}
+
+// Title returns the v2 title if set, otherwise the v1 song name.
+func (f *File) Title() string {
+	if s := f.V2.Title(); s != "" {
+		return s
+	}
+	if f.V1 != nil {
+		return f.V1.SongName
+	}
+	return ""
+}
+
+// SetTitle sets the title on the v2 tag, and on the v1 tag too if present.
+func (f *File) SetTitle(text string) {
+	f.dirty = true
+	f.V2.SetTitle(text)
+	if f.V1 != nil {
+		f.V1.SongName = text
+	}
+}
+
+// Artist returns the v2 artist if set, otherwise the v1 artist.
+func (f *File) Artist() string {
+	if s := f.V2.Artist(); s != "" {
+		return s
+	}
+	if f.V1 != nil {
+		return f.V1.Artist
+	}
+	return ""
+}
+
+// SetArtist sets the artist on the v2 tag, and on the v1 tag too if
+// present.
+func (f *File) SetArtist(text string) {
+	f.dirty = true
+	f.V2.SetArtist(text)
+	if f.V1 != nil {
+		f.V1.Artist = text
+	}
+}
+
+// Album returns the v2 album if set, otherwise the v1 album.
+func (f *File) Album() string {
+	if s := f.V2.Album(); s != "" {
+		return s
+	}
+	if f.V1 != nil {
+		return f.V1.Album
+	}
+	return ""
+}
+
+// SetAlbum sets the album on the v2 tag, and on the v1 tag too if present.
+func (f *File) SetAlbum(text string) {
+	f.dirty = true
+	f.V2.SetAlbum(text)
+	if f.V1 != nil {
+		f.V1.Album = text
+	}
+}
+
+// Year returns the v2 year if set, otherwise the v1 year.
+func (f *File) Year() string {
+	if s := f.V2.Year(); s != "" {
+		return s
+	}
+	if f.V1 != nil {
+		return f.V1.Year
+	}
+	return ""
+}
+
+// SetYear sets the year on the v2 tag, and on the v1 tag too if present.
+func (f *File) SetYear(text string) {
+	f.dirty = true
+	f.V2.SetYear(text)
+	if f.V1 != nil {
+		f.V1.Year = text
+	}
+}
+
+// Genre returns the v2 genre if set, otherwise the v1 genre name.
+func (f *File) Genre() string {
+	if s := f.V2.Genre(); s != "" {
+		return s
+	}
+	if f.V1 != nil {
+		return f.V1.GenreName()
+	}
+	return ""
+}
+
+// SetGenre sets the genre on the v2 tag, and on the v1 tag too if present
+// and name is a known standard genre.
+func (f *File) SetGenre(text string) {
+	f.dirty = true
+	f.V2.SetGenre(text)
+	if f.V1 != nil {
+		f.V1.SetGenreName(text)
+	}
+}
+
+// Comments returns the v2 comment if set, otherwise the v1 comment.
+func (f *File) Comments() string {
+	if s := f.V2.Comments(); s != "" {
+		return s
+	}
+	if f.V1 != nil {
+		return f.V1.Comment
+	}
+	return ""
+}
+
+// SetComments sets the comment on the v2 tag, and on the v1 tag too if
+// present.
+func (f *File) SetComments(text string) {
+	f.dirty = true
+	f.V2.SetComments(text)
+	if f.V1 != nil {
+		f.V1.Comment = text
+	}
+}
+
+// SyncV1FromV2 copies the common fields (title, artist, album, year,
+// genre, comment) from the v2 tag onto the v1 tag. It is a no-op unless
+// both tags are present.
+func (f *File) SyncV1FromV2() {
+	if f.V1 == nil || f.V2 == nil {
+		return
+	}
+
+	f.dirty = true
+
+	if s := f.V2.Title(); s != "" {
+		f.V1.SongName = s
+	}
+	if s := f.V2.Artist(); s != "" {
+		f.V1.Artist = s
+	}
+	if s := f.V2.Album(); s != "" {
+		f.V1.Album = s
+	}
+	if s := f.V2.Year(); s != "" {
+		f.V1.Year = s
+	}
+	if s := f.V2.Genre(); s != "" {
+		f.V1.SetGenreName(s)
+	}
+	if s := f.V2.Comments(); s != "" {
+		f.V1.Comment = s
+	}
+}
+
+// audioBytes returns the non-tag payload of the file: everything between
+// the end of the (original) v2 tag and the start of the (original) v1
+// trailer, if any.
+func (f *File) audioBytes() ([]byte, error) {
+	if f.audio != nil {
+		return f.audio, nil
+	}
+
+	audioEnd := f.size
+	if f.ContainsV1 {
+		audioEnd -= int64(f.v1Size)
+	}
+
+	return ioutil.ReadAll(io.NewSectionReader(f.stream, int64(f.originalSize), audioEnd-int64(f.originalSize)))
+}
+
+// Close writes any modified tags back to the file and closes it. The v2
+// tag is (re)written at the start of the file and the v1 tag, if any, at
+// the end; the audio data between them is preserved unchanged. Files
+// opened with OpenReader have no backing sink to write through, so Close
+// on them is a no-op; use WriteTo to serialize their tags explicitly.
+func (f *File) Close() error {
+	if f.stream == nil {
+		return nil
+	}
+
+	if !f.dirty {
+		return f.stream.Close()
+	}
+
+	audio, err := f.audioBytes()
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.stream.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	v2Bytes := f.V2.Bytes()
+	if _, err := f.stream.Write(v2Bytes); err != nil {
+		return err
+	}
+
+	if _, err := f.stream.Write(audio); err != nil {
+		return err
+	}
+
+	offset := int64(len(v2Bytes) + len(audio))
+
+	if f.V1 != nil {
+		v1Bytes := f.V1.Bytes()
+		if _, err := f.stream.Write(v1Bytes); err != nil {
+			return err
+		}
+		offset += int64(len(v1Bytes))
+	}
+
+	if err := f.stream.Truncate(offset); err != nil {
+		return err
+	}
+
+	f.originalSize = len(v2Bytes)
+	f.size = offset
+
+	return f.stream.Close()
+}
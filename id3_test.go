@@ -13,8 +13,8 @@ import (
 )
 
 const (
-	testFile        = "test.mp3"
-	chapterTestFile = "chaptest.mp3"
+	testFile        = "testdata/test.mp3"
+	chapterTestFile = "testdata/chaptest.mp3"
 )
 
 func TestOpen(t *testing.T) {
@@ -283,10 +283,10 @@ func TestChapters(t *testing.T) {
 	}
 
 	if chaps[0].Title() != "Intro" {
-		t.Errorf("got %q", chaps[0].Title)
+		t.Errorf("got %q", chaps[0].Title())
 	}
 	if chaps[8].Title() != "Get a free account!" {
-		t.Errorf("got %q", chaps[8].Title)
+		t.Errorf("got %q", chaps[8].Title())
 	}
 
 	if !chaps[0].UseTime {
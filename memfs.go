@@ -0,0 +1,159 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package id3
+
+import (
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// NewMemFS returns an FS backed entirely by memory. It is meant for
+// tests and for short-lived pipelines that tag a blob without touching
+// disk.
+func NewMemFS() FS {
+	return &memFS{files: make(map[string]*memFileData)}
+}
+
+type memFS struct {
+	mu    sync.Mutex
+	files map[string]*memFileData
+}
+
+type memFileData struct {
+	name string
+	data []byte
+}
+
+func (fs *memFS) Open(name string) (FSFile, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	d, ok := fs.files[name]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+
+	return &memFile{data: d}, nil
+}
+
+func (fs *memFS) Create(name string) (FSFile, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	d := &memFileData{name: name}
+	fs.files[name] = d
+
+	return &memFile{data: d}, nil
+}
+
+func (fs *memFS) OpenFile(name string, flag int, perm os.FileMode) (FSFile, error) {
+	fs.mu.Lock()
+	d, ok := fs.files[name]
+	if !ok {
+		if flag&(os.O_CREATE) == 0 {
+			fs.mu.Unlock()
+			return nil, os.ErrNotExist
+		}
+		d = &memFileData{name: name}
+		fs.files[name] = d
+	}
+	fs.mu.Unlock()
+
+	if flag&os.O_TRUNC != 0 {
+		d.data = nil
+	}
+
+	return &memFile{data: d}, nil
+}
+
+func (fs *memFS) Stat(name string) (os.FileInfo, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	d, ok := fs.files[name]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+
+	return memFileInfo{d}, nil
+}
+
+// memFile is an FSFile backed by a shared, growable byte slice.
+type memFile struct {
+	data *memFileData
+	pos  int64
+}
+
+func (f *memFile) Read(p []byte) (int, error) {
+	n, err := f.ReadAt(p, f.pos)
+	f.pos += int64(n)
+	return n, err
+}
+
+func (f *memFile) ReadAt(p []byte, off int64) (int, error) {
+	if off >= int64(len(f.data.data)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, f.data.data[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+func (f *memFile) Write(p []byte) (int, error) {
+	f.grow(f.pos + int64(len(p)))
+	n := copy(f.data.data[f.pos:], p)
+	f.pos += int64(n)
+	return n, nil
+}
+
+func (f *memFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		f.pos = offset
+	case io.SeekCurrent:
+		f.pos += offset
+	case io.SeekEnd:
+		f.pos = int64(len(f.data.data)) + offset
+	}
+
+	return f.pos, nil
+}
+
+func (f *memFile) Truncate(size int64) error {
+	f.grow(size)
+	f.data.data = f.data.data[:size]
+	return nil
+}
+
+func (f *memFile) Close() error {
+	return nil
+}
+
+func (f *memFile) grow(size int64) {
+	if int64(len(f.data.data)) >= size {
+		return
+	}
+
+	grown := make([]byte, size)
+	copy(grown, f.data.data)
+	f.data.data = grown
+}
+
+type memFileInfo struct {
+	d *memFileData
+}
+
+func (fi memFileInfo) Name() string       { return fi.d.name }
+func (fi memFileInfo) Size() int64        { return int64(len(fi.d.data)) }
+func (fi memFileInfo) Mode() os.FileMode  { return 0666 }
+func (fi memFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi memFileInfo) IsDir() bool        { return false }
+func (fi memFileInfo) Sys() interface{}   { return nil }
@@ -0,0 +1,46 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package v1
+
+// Genres is the standard ID3v1 genre table, indexed by the single genre
+// byte stored in a V1Tag. Index 255 (unset) is not a valid table entry.
+var Genres = [...]string{
+	"Blues", "Classic Rock", "Country", "Dance", "Disco", "Funk", "Grunge",
+	"Hip-Hop", "Jazz", "Metal", "New Age", "Oldies", "Other", "Pop", "R&B",
+	"Rap", "Reggae", "Rock", "Techno", "Industrial", "Alternative", "Ska",
+	"Death Metal", "Pranks", "Soundtrack", "Euro-Techno", "Ambient",
+	"Trip-Hop", "Vocal", "Jazz+Funk", "Fusion", "Trance", "Classical",
+	"Instrumental", "Acid", "House", "Game", "Sound Clip", "Gospel",
+	"Noise", "AlternRock", "Bass", "Soul", "Punk", "Space", "Meditative",
+	"Instrumental Pop", "Instrumental Rock", "Ethnic", "Gothic",
+	"Darkwave", "Techno-Industrial", "Electronic", "Pop-Folk",
+	"Eurodance", "Dream", "Southern Rock", "Comedy", "Cult", "Gangsta",
+	"Top 40", "Christian Rap", "Pop/Funk", "Jungle", "Native American",
+	"Cabaret", "New Wave", "Psychedelic", "Rave", "Showtunes", "Trailer",
+	"Lo-Fi", "Tribal", "Acid Punk", "Acid Jazz", "Polka", "Retro",
+	"Musical", "Rock & Roll", "Hard Rock",
+}
+
+// GenreOf returns the genre name for the given ID3v1 genre byte, or "" if
+// it falls outside the standard table.
+func GenreOf(b byte) string {
+	if int(b) < len(Genres) {
+		return Genres[b]
+	}
+
+	return ""
+}
+
+// IndexOfGenre returns the ID3v1 genre byte for name, and ok=false if name
+// is not in the standard table.
+func IndexOfGenre(name string) (b byte, ok bool) {
+	for i, g := range Genres {
+		if g == name {
+			return byte(i), true
+		}
+	}
+
+	return 0, false
+}
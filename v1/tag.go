@@ -0,0 +1,217 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package v1 implements the reading and writing of ID3v1 and ID3v1.1
+// tags, including the "TAG+" Enhanced extension.
+package v1
+
+import (
+	"bytes"
+	"io"
+)
+
+const (
+	// TagSize is the length in bytes of a standard ID3v1/ID3v1.1 tag.
+	TagSize = 128
+
+	// EnhancedTagSize is the length in bytes of the "TAG+" Enhanced block
+	// that, when present, immediately precedes the standard tag.
+	EnhancedTagSize = 227
+
+	identifier         = "TAG"
+	enhancedIdentifier = "TAG+"
+)
+
+// V1Tag represents a standard, 128-byte ID3v1 tag. When Enhanced is true,
+// it was read alongside a preceding 227-byte "TAG+" block, whose fields
+// are also populated.
+type V1Tag struct {
+	SongName string
+	Artist   string
+	Album    string
+	Year     string
+	Comment  string
+	Genre    byte
+
+	Enhanced  bool
+	Speed     byte
+	FreeGenre string
+	StartTime string
+	EndTime   string
+}
+
+// GenreName returns the standard genre table name for the tag's Genre
+// byte, or "" if it falls outside the table.
+func (t *V1Tag) GenreName() string {
+	return GenreOf(t.Genre)
+}
+
+// SetGenreName sets Genre to the table index of name, if name is a known
+// standard genre.
+func (t *V1Tag) SetGenreName(name string) {
+	if b, ok := IndexOfGenre(name); ok {
+		t.Genre = b
+	}
+}
+
+// Size returns the number of bytes Bytes will produce.
+func (t *V1Tag) Size() int {
+	if t.Enhanced {
+		return EnhancedTagSize + TagSize
+	}
+
+	return TagSize
+}
+
+// Bytes serializes the tag, including its "TAG+" Enhanced block if
+// present.
+func (t *V1Tag) Bytes() []byte {
+	buf := new(bytes.Buffer)
+
+	if t.Enhanced {
+		buf.WriteString(enhancedIdentifier)
+		buf.Write(fixedString(t.SongName, 60))
+		buf.Write(fixedString(t.Artist, 60))
+		buf.Write(fixedString(t.Album, 60))
+		buf.WriteByte(t.Speed)
+		buf.Write(fixedString(t.FreeGenre, 30))
+		buf.Write(fixedString(t.StartTime, 6))
+		buf.Write(fixedString(t.EndTime, 6))
+	}
+
+	buf.WriteString(identifier)
+	buf.Write(fixedString(t.SongName, 30))
+	buf.Write(fixedString(t.Artist, 30))
+	buf.Write(fixedString(t.Album, 30))
+	buf.Write(fixedString(t.Year, 4))
+	buf.Write(fixedString(t.Comment, 30))
+	buf.WriteByte(t.Genre)
+
+	return buf.Bytes()
+}
+
+// V11Tag is a V1Tag extended per the ID3v1.1 convention: the last two
+// bytes of the comment field are repurposed to store a track number,
+// signalled by a zero byte immediately before it.
+type V11Tag struct {
+	V1Tag
+	Track byte
+}
+
+// Bytes serializes the tag, storing Track in the last two bytes of the
+// comment field as ID3v1.1 specifies.
+func (t *V11Tag) Bytes() []byte {
+	b := t.V1Tag.Bytes()
+	commentStart := len(b) - TagSize + len(identifier) + 30 + 30 + 30 + 4
+
+	copy(b[commentStart:commentStart+28], fixedString(t.Comment, 28))
+	b[commentStart+28] = 0
+	b[commentStart+29] = t.Track
+
+	return b
+}
+
+// fixedString returns s truncated or null-padded to exactly size bytes.
+func fixedString(s string, size int) []byte {
+	b := make([]byte, size)
+	copy(b, s)
+
+	return b
+}
+
+// Read locates and parses an ID3v1 (and, if present, "TAG+" Enhanced) tag
+// at the end of a size-byte stream. It returns a nil tag and nil error if
+// no "TAG" trailer is found. The returned value is either a *V1Tag or,
+// when the comment field carries an ID3v1.1 track number, a *V11Tag.
+func Read(reader io.ReaderAt, size int64) (*V1Tag, error) {
+	v11, err := ReadV11(reader, size)
+	if err != nil || v11 == nil {
+		return nil, err
+	}
+
+	return &v11.V1Tag, nil
+}
+
+// ReadV11 behaves like Read, but also reports the ID3v1.1 track number
+// when present. It returns a nil tag when no "TAG" trailer is found.
+func ReadV11(reader io.ReaderAt, size int64) (*V11Tag, error) {
+	if size < TagSize {
+		return nil, nil
+	}
+
+	base := make([]byte, TagSize)
+	if _, err := reader.ReadAt(base, size-TagSize); err != nil {
+		return nil, err
+	}
+
+	if string(base[0:len(identifier)]) != identifier {
+		return nil, nil
+	}
+
+	tag := &V11Tag{V1Tag: V1Tag{
+		SongName: trimString(base[3:33]),
+		Artist:   trimString(base[33:63]),
+		Album:    trimString(base[63:93]),
+		Year:     trimString(base[93:97]),
+		Genre:    base[127],
+	}}
+
+	comment := base[97:127]
+	if comment[28] == 0 && comment[29] != 0 {
+		tag.Comment = trimString(comment[0:28])
+		tag.Track = comment[29]
+	} else {
+		tag.Comment = trimString(comment)
+	}
+
+	if size >= TagSize+EnhancedTagSize {
+		readEnhanced(reader, size, &tag.V1Tag)
+	}
+
+	return tag, nil
+}
+
+func readEnhanced(reader io.ReaderAt, size int64, tag *V1Tag) {
+	enhanced := make([]byte, EnhancedTagSize)
+	if _, err := reader.ReadAt(enhanced, size-TagSize-EnhancedTagSize); err != nil {
+		return
+	}
+
+	if string(enhanced[0:len(enhancedIdentifier)]) != enhancedIdentifier {
+		return
+	}
+
+	tag.Enhanced = true
+	tag.SongName = firstNonEmpty(trimString(enhanced[4:64]), tag.SongName)
+	tag.Artist = firstNonEmpty(trimString(enhanced[64:124]), tag.Artist)
+	tag.Album = firstNonEmpty(trimString(enhanced[124:184]), tag.Album)
+	tag.Speed = enhanced[184]
+	tag.FreeGenre = trimString(enhanced[185:215])
+	tag.StartTime = trimString(enhanced[215:221])
+	tag.EndTime = trimString(enhanced[221:227])
+}
+
+func firstNonEmpty(a, b string) string {
+	if a != "" {
+		return a
+	}
+
+	return b
+}
+
+// trimString trims everything from the first null byte onward, then any
+// trailing spaces, from a fixed-width ID3v1 field.
+func trimString(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+
+	return string(bytes.TrimRight(b, " "))
+}
+
+// Write serializes tag (a *V1Tag or *V11Tag) to w.
+func Write(w io.Writer, tag interface{ Bytes() []byte }) error {
+	_, err := w.Write(tag.Bytes())
+	return err
+}
@@ -0,0 +1,155 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package v1
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestReadV11_Track(t *testing.T) {
+	tag := &V11Tag{
+		V1Tag: V1Tag{
+			SongName: "Song",
+			Artist:   "Artist",
+			Album:    "Album",
+			Year:     "2013",
+			Comment:  "a comment",
+			Genre:    17,
+		},
+		Track: 9,
+	}
+
+	data := tag.Bytes()
+	if len(data) != TagSize {
+		t.Fatalf("Bytes: got %d bytes, want %d", len(data), TagSize)
+	}
+
+	parsed, err := ReadV11(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("ReadV11: %v", err)
+	}
+	if parsed == nil {
+		t.Fatal("ReadV11: got nil tag")
+	}
+
+	if parsed.SongName != tag.SongName {
+		t.Errorf("SongName: got %q, want %q", parsed.SongName, tag.SongName)
+	}
+	if parsed.Comment != tag.Comment {
+		t.Errorf("Comment: got %q, want %q", parsed.Comment, tag.Comment)
+	}
+	if parsed.Track != tag.Track {
+		t.Errorf("Track: got %d, want %d", parsed.Track, tag.Track)
+	}
+	if parsed.GenreName() != GenreOf(17) {
+		t.Errorf("GenreName: got %q, want %q", parsed.GenreName(), GenreOf(17))
+	}
+}
+
+func TestRead_PlainV1(t *testing.T) {
+	// A comment long enough to fill all 30 bytes leaves no room for the
+	// zero byte ID3v1.1 track numbers are signalled with, so Read/ReadV11
+	// must report it as a plain V1Tag with no track.
+	tag := &V1Tag{Comment: "this comment is exactly thirty"[:30]}
+	data := tag.Bytes()
+
+	parsed, err := Read(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if parsed.Comment != tag.Comment {
+		t.Errorf("Comment: got %q, want %q", parsed.Comment, tag.Comment)
+	}
+
+	v11, err := ReadV11(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("ReadV11: %v", err)
+	}
+	if v11.Track != 0 {
+		t.Errorf("Track: got %d, want 0", v11.Track)
+	}
+}
+
+func TestRead_NoTag(t *testing.T) {
+	tag, err := Read(bytes.NewReader([]byte("not a tag, just audio bytes")), 28)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if tag != nil {
+		t.Errorf("Read: got %+v, want nil", tag)
+	}
+}
+
+func TestReadV11_Enhanced(t *testing.T) {
+	tag := &V11Tag{V1Tag: V1Tag{
+		SongName:  "Song",
+		Artist:    "Artist",
+		Album:     "Album",
+		Year:      "2013",
+		Genre:     12,
+		Enhanced:  true,
+		Speed:     2,
+		FreeGenre: "Chiptune",
+		StartTime: "000000",
+		EndTime:   "012345",
+	}}
+
+	data := tag.Bytes()
+	if len(data) != EnhancedTagSize+TagSize {
+		t.Fatalf("Bytes: got %d bytes, want %d", len(data), EnhancedTagSize+TagSize)
+	}
+
+	parsed, err := ReadV11(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("ReadV11: %v", err)
+	}
+
+	if !parsed.Enhanced {
+		t.Fatal("Enhanced: got false, want true")
+	}
+	if parsed.Speed != tag.Speed {
+		t.Errorf("Speed: got %d, want %d", parsed.Speed, tag.Speed)
+	}
+	if parsed.FreeGenre != tag.FreeGenre {
+		t.Errorf("FreeGenre: got %q, want %q", parsed.FreeGenre, tag.FreeGenre)
+	}
+	if parsed.StartTime != tag.StartTime || parsed.EndTime != tag.EndTime {
+		t.Errorf("StartTime/EndTime: got %q/%q, want %q/%q", parsed.StartTime, parsed.EndTime, tag.StartTime, tag.EndTime)
+	}
+	// The enhanced SongName/Artist/Album fields are wider than the
+	// standard ones and should win when both are non-empty.
+	if parsed.SongName != tag.SongName {
+		t.Errorf("SongName: got %q, want %q", parsed.SongName, tag.SongName)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	tag := &V1Tag{SongName: "Song"}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, tag); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), tag.Bytes()) {
+		t.Errorf("Write: got %x, want %x", buf.Bytes(), tag.Bytes())
+	}
+}
+
+func TestSetGenreName(t *testing.T) {
+	tag := &V1Tag{}
+
+	tag.SetGenreName("Metal")
+	if tag.GenreName() != "Metal" {
+		t.Errorf("SetGenreName: got %q, want %q", tag.GenreName(), "Metal")
+	}
+
+	// An unknown genre name must leave the previously set genre alone.
+	tag.SetGenreName("Not A Real Genre")
+	if tag.GenreName() != "Metal" {
+		t.Errorf("SetGenreName: unknown name changed genre to %q", tag.GenreName())
+	}
+}
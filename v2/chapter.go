@@ -0,0 +1,288 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package v2
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// ignoredOffset is the sentinel value the Chapter Frame Addendum uses to
+// mark a byte offset field as unused in favor of the millisecond timestamps.
+const ignoredOffset uint32 = 0xffffffff
+
+// ChapterFrame represents a CHAP frame: a named time range within the
+// audio, optionally carrying embedded subframes such as a TIT2 title.
+type ChapterFrame struct {
+	FrameHead
+	ElementID   string
+	StartTime   uint32
+	EndTime     uint32
+	StartOffset uint32
+	EndOffset   uint32
+	UseTime     bool
+	SubFrames   []Framer
+}
+
+// NewChapterFrame returns a new CHAP frame spanning [start, end), with no
+// byte offsets and no subframes yet.
+func NewChapterFrame(elementID string, start, end time.Duration) *ChapterFrame {
+	return &ChapterFrame{
+		FrameHead:   NewFrameHead(V24FrameTypeMap["CHAP"], LatestVersion),
+		ElementID:   elementID,
+		StartTime:   uint32(start / time.Millisecond),
+		EndTime:     uint32(end / time.Millisecond),
+		StartOffset: ignoredOffset,
+		EndOffset:   ignoredOffset,
+		UseTime:     true,
+	}
+}
+
+func parseChapterFrame(head FrameHead, data []byte) Framer {
+	elementID, rest := split(EncodingISO8859_1, data)
+	if len(rest) < 16 {
+		return &ChapterFrame{FrameHead: head, ElementID: string(elementID)}
+	}
+
+	startTime := binary.BigEndian.Uint32(rest[0:4])
+	endTime := binary.BigEndian.Uint32(rest[4:8])
+	startOffset := binary.BigEndian.Uint32(rest[8:12])
+	endOffset := binary.BigEndian.Uint32(rest[12:16])
+
+	return &ChapterFrame{
+		FrameHead:   head,
+		ElementID:   string(elementID),
+		StartTime:   startTime,
+		EndTime:     endTime,
+		StartOffset: startOffset,
+		EndOffset:   endOffset,
+		UseTime:     startOffset == ignoredOffset && endOffset == ignoredOffset,
+		SubFrames:   parseSubFrames(head.version, rest[16:]),
+	}
+}
+
+// Title returns the text of the embedded TIT2 subframe, if any.
+func (f ChapterFrame) Title() string {
+	return f.subFrameText("TIT2")
+}
+
+// Description returns the text of the embedded TIT3 subframe, if any.
+func (f ChapterFrame) Description() string {
+	return f.subFrameText("TIT3")
+}
+
+func (f ChapterFrame) subFrameText(id string) string {
+	for _, sf := range f.SubFrames {
+		if sf.Id() == id {
+			if tf, ok := sf.(*TextFrame); ok {
+				return tf.Text()
+			}
+		}
+	}
+
+	return ""
+}
+
+// SetTitle sets (or adds) the embedded TIT2 subframe used as the
+// chapter's title.
+func (f *ChapterFrame) SetTitle(title string) {
+	f.setSubFrameText("TIT2", title)
+}
+
+// SetDescription sets (or adds) the embedded TIT3 subframe used as the
+// chapter's description.
+func (f *ChapterFrame) SetDescription(description string) {
+	f.setSubFrameText("TIT3", description)
+}
+
+func (f *ChapterFrame) setSubFrameText(id, text string) {
+	for _, sf := range f.SubFrames {
+		if sf.Id() == id {
+			if tf, ok := sf.(*TextFrame); ok {
+				tf.SetText(text)
+				return
+			}
+		}
+	}
+
+	ft, ok := frameTypeMap(f.version)[id]
+	if !ok {
+		ft = FrameType{id: id, description: id, constructor: parseTextFrame}
+	}
+	f.AddSubFrame(NewTextFrame(ft, text))
+}
+
+// SetStartOffset sets the chapter's start byte offset. Passing
+// 0xFFFFFFFF marks it ignored in favor of StartTime.
+func (f *ChapterFrame) SetStartOffset(offset uint32) {
+	f.StartOffset = offset
+	f.UseTime = f.StartOffset == ignoredOffset && f.EndOffset == ignoredOffset
+}
+
+// SetEndOffset sets the chapter's end byte offset. Passing 0xFFFFFFFF
+// marks it ignored in favor of EndTime.
+func (f *ChapterFrame) SetEndOffset(offset uint32) {
+	f.EndOffset = offset
+	f.UseTime = f.StartOffset == ignoredOffset && f.EndOffset == ignoredOffset
+}
+
+// AddSubFrame appends an embedded ID3v2 frame, such as a TIT2 title or an
+// APIC image, to the chapter.
+func (f *ChapterFrame) AddSubFrame(frame Framer) {
+	f.SubFrames = append(f.SubFrames, frame)
+}
+
+func (f ChapterFrame) Size() int {
+	return FrameHeaderSize + len(f.body())
+}
+
+func (f ChapterFrame) body() []byte {
+	body := append([]byte(f.ElementID), 0)
+
+	offsets := make([]byte, 16)
+	binary.BigEndian.PutUint32(offsets[0:4], f.StartTime)
+	binary.BigEndian.PutUint32(offsets[4:8], f.EndTime)
+	binary.BigEndian.PutUint32(offsets[8:12], f.StartOffset)
+	binary.BigEndian.PutUint32(offsets[12:16], f.EndOffset)
+	body = append(body, offsets...)
+
+	for _, sf := range f.SubFrames {
+		body = append(body, sf.Bytes()...)
+	}
+
+	return body
+}
+
+func (f ChapterFrame) Bytes() []byte {
+	return append(f.FrameHead.Bytes(len(f.body())), f.body()...)
+}
+
+func (f ChapterFrame) String() string {
+	return f.ElementID
+}
+
+// TOCFrame represents a CTOC frame: an ordered or unordered grouping of
+// child element ids, used to build a chapter table of contents.
+type TOCFrame struct {
+	FrameHead
+	Element       string
+	TopLevel      bool
+	Ordered       bool
+	ChildElements []string
+	SubFrames     []Framer
+}
+
+const (
+	tocOrderedFlag  byte = 1 << 0
+	tocTopLevelFlag byte = 1 << 1
+)
+
+// NewTOCFrame returns a new CTOC frame listing children, in order, as the
+// elementID ids of child CHAP/CTOC frames.
+func NewTOCFrame(elementID string, topLevel, ordered bool, children []string) *TOCFrame {
+	return &TOCFrame{
+		FrameHead:     NewFrameHead(V24FrameTypeMap["CTOC"], LatestVersion),
+		Element:       elementID,
+		TopLevel:      topLevel,
+		Ordered:       ordered,
+		ChildElements: children,
+	}
+}
+
+// AddSubFrame appends an embedded ID3v2 frame, such as a TIT2 title, to
+// the table of contents entry.
+func (f *TOCFrame) AddSubFrame(frame Framer) {
+	f.SubFrames = append(f.SubFrames, frame)
+}
+
+func parseTOCFrame(head FrameHead, data []byte) Framer {
+	element, rest := split(EncodingISO8859_1, data)
+	if len(rest) < 2 {
+		return &TOCFrame{FrameHead: head, Element: string(element)}
+	}
+
+	flags := rest[0]
+	count := int(rest[1])
+	rest = rest[2:]
+
+	// count comes straight from the (untrusted) frame body and may claim
+	// more children than rest actually holds; stop once rest is
+	// exhausted instead of fabricating empty-string children for the
+	// remainder, which would make Bytes() re-serialize a different byte
+	// length than was parsed.
+	children := make([]string, 0, count)
+	for i := 0; i < count && len(rest) > 0; i++ {
+		var child []byte
+		child, rest = split(EncodingISO8859_1, rest)
+		children = append(children, string(child))
+	}
+
+	return &TOCFrame{
+		FrameHead:     head,
+		Element:       string(element),
+		TopLevel:      flags&tocTopLevelFlag != 0,
+		Ordered:       flags&tocOrderedFlag != 0,
+		ChildElements: children,
+		SubFrames:     parseSubFrames(head.version, rest),
+	}
+}
+
+func (f TOCFrame) Size() int {
+	return FrameHeaderSize + len(f.body())
+}
+
+func (f TOCFrame) body() []byte {
+	body := append([]byte(f.Element), 0)
+
+	var flags byte
+	if f.Ordered {
+		flags |= tocOrderedFlag
+	}
+	if f.TopLevel {
+		flags |= tocTopLevelFlag
+	}
+	body = append(body, flags, byte(len(f.ChildElements)))
+
+	for _, child := range f.ChildElements {
+		body = append(body, []byte(child)...)
+		body = append(body, 0)
+	}
+
+	for _, sf := range f.SubFrames {
+		body = append(body, sf.Bytes()...)
+	}
+
+	return body
+}
+
+func (f TOCFrame) Bytes() []byte {
+	return append(f.FrameHead.Bytes(len(f.body())), f.body()...)
+}
+
+func (f TOCFrame) String() string {
+	return f.Element
+}
+
+// parseSubFrames parses a run of embedded ID3v2 frames, as found at the
+// tail of CHAP and CTOC frame bodies.
+func parseSubFrames(version byte, data []byte) []Framer {
+	var frames []Framer
+
+	for len(data) >= FrameHeaderSize {
+		if data[0] == 0 {
+			break
+		}
+
+		frame, size, err := parseFrame(version, data)
+		if err != nil || frame == nil {
+			break
+		}
+
+		frames = append(frames, frame)
+		data = data[size:]
+	}
+
+	return frames
+}
@@ -0,0 +1,73 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package v2
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestChapterFrame_RoundTrip(t *testing.T) {
+	chap := NewChapterFrame("chp0", 0, 15*time.Second)
+	chap.SetTitle("Intro")
+
+	parsed, size, err := parseFrame(4, chap.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if size != chap.Size() {
+		t.Errorf("got size %d, want %d", size, chap.Size())
+	}
+
+	result, ok := parsed.(*ChapterFrame)
+	if !ok {
+		t.Fatal("couldn't cast frame")
+	}
+
+	if result.ElementID != "chp0" {
+		t.Errorf("got element id %q", result.ElementID)
+	}
+
+	if result.EndTime != 15000 {
+		t.Errorf("got end time %d", result.EndTime)
+	}
+
+	if title := result.Title(); title != "Intro" {
+		t.Errorf("got title %q", title)
+	}
+}
+
+func TestTOCFrame_RoundTrip(t *testing.T) {
+	toc := NewTOCFrame("toc", true, true, []string{"chp0", "chp1"})
+	toc.AddSubFrame(NewTextFrame(V24FrameTypeMap["TIT2"], "Chapters"))
+
+	parsed, size, err := parseFrame(4, toc.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if size != toc.Size() {
+		t.Errorf("got size %d, want %d", size, toc.Size())
+	}
+
+	result, ok := parsed.(*TOCFrame)
+	if !ok {
+		t.Fatal("couldn't cast frame")
+	}
+
+	if !bytes.Equal([]byte(result.Element), []byte("toc")) {
+		t.Errorf("got element %q", result.Element)
+	}
+
+	if len(result.ChildElements) != 2 || result.ChildElements[1] != "chp1" {
+		t.Errorf("got children %v", result.ChildElements)
+	}
+
+	if len(result.SubFrames) != 1 || result.SubFrames[0].(*TextFrame).Text() != "Chapters" {
+		t.Errorf("got subframes %v", result.SubFrames)
+	}
+}
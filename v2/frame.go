@@ -0,0 +1,140 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package v2
+
+import (
+	"bytes"
+)
+
+// Text encodings, as stored in the first byte of most text-bearing frames.
+const (
+	EncodingISO8859_1 byte = iota
+	EncodingUTF16BOM
+	EncodingUTF16BE
+	EncodingUTF8
+)
+
+// DataFrame is a fallback frame type that keeps a frame's body as opaque
+// bytes. It is used for frame ids this package has no specific parser for.
+type DataFrame struct {
+	FrameHead
+	data []byte
+}
+
+func NewDataFrame(ft FrameType, data []byte) Framer {
+	return &DataFrame{NewFrameHead(ft, LatestVersion), data}
+}
+
+func parseDataFrame(head FrameHead, data []byte) Framer {
+	return &DataFrame{head, data}
+}
+
+func (f DataFrame) Size() int {
+	return FrameHeaderSize + len(f.data)
+}
+
+func (f DataFrame) Data() []byte {
+	return f.data
+}
+
+func (f *DataFrame) SetData(data []byte) {
+	f.data = data
+}
+
+func (f DataFrame) Bytes() []byte {
+	return append(f.FrameHead.Bytes(len(f.data)), f.data...)
+}
+
+func (f DataFrame) String() string {
+	return string(f.data)
+}
+
+// TextFrame represents a simple text-information frame, such as TIT2, TPE1,
+// TALB, TYER or TCON: a one-byte encoding followed by the encoded text.
+type TextFrame struct {
+	FrameHead
+	encoding byte
+	text     string
+}
+
+// NewTextFrame returns a new text frame of the given type, encoded as UTF-8.
+func NewTextFrame(ft FrameType, text string) *TextFrame {
+	return &TextFrame{NewFrameHead(ft, LatestVersion), EncodingUTF8, text}
+}
+
+func parseTextFrame(head FrameHead, data []byte) Framer {
+	if len(data) == 0 {
+		return &TextFrame{head, EncodingISO8859_1, ""}
+	}
+
+	encoding := data[0]
+	return &TextFrame{head, encoding, decodeText(encoding, data[1:])}
+}
+
+func (f TextFrame) Size() int {
+	return FrameHeaderSize + 1 + len(encodeText(f.encoding, f.text))
+}
+
+func (f TextFrame) Text() string {
+	return f.text
+}
+
+func (f *TextFrame) SetText(text string) {
+	f.text = text
+}
+
+func (f TextFrame) Bytes() []byte {
+	body := append([]byte{f.encoding}, encodeText(f.encoding, f.text)...)
+	return append(f.FrameHead.Bytes(len(body)), body...)
+}
+
+func (f TextFrame) String() string {
+	return f.text
+}
+
+// split breaks data on the encoding-appropriate null terminator, returning
+// the first field and the remainder. For UTF-16, the terminator is a
+// 2-byte null code unit, so the search only considers 2-byte-aligned
+// positions; scanning byte-by-byte could mistake a null high or low byte
+// inside a code unit for the terminator and cut the field mid-character.
+func split(encoding byte, data []byte) (field, rest []byte) {
+	if encoding == EncodingUTF16BOM || encoding == EncodingUTF16BE {
+		for i := 0; i+1 < len(data); i += 2 {
+			if data[i] == 0 && data[i+1] == 0 {
+				return data[:i], data[i+2:]
+			}
+		}
+		return data, nil
+	}
+
+	i := bytes.Index(data, []byte{0})
+	if i < 0 {
+		return data, nil
+	}
+
+	return data[:i], data[i+1:]
+}
+
+func decodeText(encoding byte, data []byte) string {
+	switch encoding {
+	case EncodingUTF16BOM:
+		return decodeUTF16(data, false)
+	case EncodingUTF16BE:
+		return decodeUTF16(data, true)
+	default:
+		return string(data)
+	}
+}
+
+func encodeText(encoding byte, text string) []byte {
+	switch encoding {
+	case EncodingUTF16BOM:
+		return encodeUTF16(text, false)
+	case EncodingUTF16BE:
+		return encodeUTF16(text, true)
+	default:
+		return []byte(text)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package v2
+
+import (
+	"errors"
+)
+
+// textFrameIds lists the frame ids handled by the generic TextFrame parser.
+var textFrameIds = []string{
+	"TALB", "TBPM", "TCOM", "TCON", "TCOP", "TDAT", "TDLY", "TENC", "TEXT",
+	"TFLT", "TIME", "TIT1", "TIT2", "TIT3", "TKEY", "TLAN", "TLEN", "TMED",
+	"TOAL", "TOFN", "TOLY", "TOPE", "TORY", "TOWN", "TPE1", "TPE2", "TPE3",
+	"TPE4", "TPOS", "TPUB", "TRCK", "TRDA", "TRSN", "TRSO", "TSIZ", "TSRC",
+	"TSSE", "TYER", "TXXX",
+}
+
+// unsynchTextFrameIds lists the frame ids handled by the UnsynchTextFrame
+// parser: comments and lyrics, both of which carry a language and an
+// optional short description ahead of their free-text body.
+var unsynchTextFrameIds = []string{"COMM", "USLT"}
+
+func newFrameTypeMap() map[string]FrameType {
+	m := make(map[string]FrameType)
+
+	for _, id := range textFrameIds {
+		m[id] = FrameType{id: id, description: id, constructor: parseTextFrame}
+	}
+
+	for _, id := range unsynchTextFrameIds {
+		m[id] = FrameType{id: id, description: id, constructor: parseUnsynchTextFrame}
+	}
+
+	m["CHAP"] = FrameType{id: "CHAP", description: "Chapter", constructor: parseChapterFrame}
+	m["CTOC"] = FrameType{id: "CTOC", description: "Table of contents", constructor: parseTOCFrame}
+	m["APIC"] = FrameType{id: "APIC", description: "Attached picture", constructor: parsePictureFrame}
+
+	return m
+}
+
+// V23FrameTypeMap and V24FrameTypeMap hold the known frame types for
+// ID3v2.3 and ID3v2.4 tags respectively. The two versions share the same
+// frame ids for every frame this package understands.
+//
+// These are populated in init() rather than in the var declaration
+// itself: their constructors (e.g. parseChapterFrame) parse subframes by
+// calling back into frameTypeMap, which reads these same vars, and the
+// compiler flags that as an initialization cycle even though nothing is
+// read before init() runs.
+var (
+	V23FrameTypeMap map[string]FrameType
+	V24FrameTypeMap map[string]FrameType
+)
+
+func init() {
+	V23FrameTypeMap = newFrameTypeMap()
+	V24FrameTypeMap = newFrameTypeMap()
+}
+
+func frameTypeMap(version byte) map[string]FrameType {
+	if version >= 4 {
+		return V24FrameTypeMap
+	}
+
+	return V23FrameTypeMap
+}
+
+// parseFrame parses a single frame (header and body) from the front of
+// data, returning the frame, the total number of bytes it occupied, and
+// any error.
+func parseFrame(version byte, data []byte) (Framer, int, error) {
+	if len(data) < FrameHeaderSize {
+		return nil, 0, errors.New("v2: not enough data for a frame header")
+	}
+
+	id := string(data[:4])
+	bodySize := ByteToInt(data[4:8], version >= 4)
+	statusFlags := data[8]
+	formatFlags := data[9]
+
+	total := FrameHeaderSize + bodySize
+	if bodySize < 0 || total > len(data) {
+		return nil, 0, errors.New("v2: frame size exceeds remaining tag data")
+	}
+
+	body := data[FrameHeaderSize:total]
+
+	ft, ok := frameTypeMap(version)[id]
+	if !ok {
+		ft = FrameType{id: id, description: id, constructor: parseDataFrame}
+	}
+
+	head := FrameHead{FrameType: ft, version: version, statusFlags: statusFlags, formatFlags: formatFlags}
+
+	return ft.constructor(head, body), total, nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package v2
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "regenerate golden files in testdata/golden")
+
+// checkGolden compares tag.Bytes() against the named file under
+// testdata/golden, rewriting it first if -update was passed, and checks
+// that the golden bytes parse back into a tag of the same size.
+func checkGolden(t *testing.T, name string, tag *Tag) {
+	t.Helper()
+
+	path := filepath.Join("testdata", "golden", name)
+	got := tag.Bytes()
+
+	if *update {
+		if err := ioutil.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s: Bytes() = %x, want %x", name, got, want)
+	}
+
+	reparsed, err := ParseTag(bytes.NewReader(want))
+	if err != nil {
+		t.Fatalf("%s: ParseTag: %v", name, err)
+	}
+	if reparsed.Size() != len(want) {
+		t.Errorf("%s: reparsed Size() = %d, want %d", name, reparsed.Size(), len(want))
+	}
+}
+
+func TestGoldenBareV23(t *testing.T) {
+	tag := NewTag(3)
+	tag.SetPadding(0)
+
+	checkGolden(t, "bare_v23.bin", tag)
+}
+
+func TestGoldenV24UTF8Title(t *testing.T) {
+	tag := NewTag(4)
+	tag.SetPadding(0)
+	tag.SetTitle("Héllo")
+
+	checkGolden(t, "v24_utf8_title.bin", tag)
+
+	reparsed, err := ParseTag(bytes.NewReader(tag.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reparsed.Title(); got != "Héllo" {
+		t.Errorf("Title() = %q, want %q", got, "Héllo")
+	}
+}
+
+func TestGoldenChapterTOC(t *testing.T) {
+	tag := NewTag(4)
+	tag.SetPadding(0)
+
+	chap := NewChapterFrame("chp0", 0, 15*time.Second)
+	chap.SetTitle("Intro")
+	toc := NewTOCFrame("toc", true, true, []string{"chp0"})
+
+	tag.AddFrames(chap, toc)
+
+	checkGolden(t, "chap_ctoc.bin", tag)
+
+	reparsed, err := ParseTag(bytes.NewReader(tag.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rchap, ok := reparsed.Frame("CHAP").(*ChapterFrame)
+	if !ok {
+		t.Fatal("CHAP frame missing after reparse")
+	}
+	if got := rchap.Title(); got != "Intro" {
+		t.Errorf("Title() = %q, want %q", got, "Intro")
+	}
+	rtoc, ok := reparsed.Frame("CTOC").(*TOCFrame)
+	if !ok {
+		t.Fatal("CTOC frame missing after reparse")
+	}
+	if len(rtoc.ChildElements) != 1 || rtoc.ChildElements[0] != "chp0" {
+		t.Errorf("ChildElements = %v, want [chp0]", rtoc.ChildElements)
+	}
+}
+
+func TestGoldenUTF16Comment(t *testing.T) {
+	tag := NewTag(3)
+	tag.SetPadding(0)
+
+	comm := NewUnsynchTextFrame(V23FrameTypeMap["COMM"], "", "✓")
+	comm.SetEncoding(EncodingUTF16BOM)
+	tag.AddFrames(comm)
+
+	checkGolden(t, "utf16_comment.bin", tag)
+
+	reparsed, err := ParseTag(bytes.NewReader(tag.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reparsed.Comments(); got != "✓" {
+		t.Errorf("Comments() = %q, want %q", got, "✓")
+	}
+}
+
+func TestGoldenAPIC(t *testing.T) {
+	tag := NewTag(3)
+	tag.SetPadding(0)
+
+	pic := NewPictureFrame("image/jpeg", PictureTypeFrontCover, "cover", []byte{0xff, 0xd8, 0xff, 0xd9})
+	tag.AddFrames(pic)
+
+	checkGolden(t, "apic.bin", tag)
+
+	reparsed, err := ParseTag(bytes.NewReader(tag.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rpic, ok := reparsed.Frame("APIC").(*PictureFrame)
+	if !ok {
+		t.Fatal("APIC frame missing after reparse")
+	}
+	if got := rpic.MIMEType(); got != "image/jpeg" {
+		t.Errorf("MIMEType() = %q, want %q", got, "image/jpeg")
+	}
+}
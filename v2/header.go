@@ -0,0 +1,101 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package v2
+
+import (
+	"errors"
+	"io"
+)
+
+const (
+	// HeaderSize is the number of bytes in an ID3v2 tag header.
+	HeaderSize = 10
+
+	identifier = "ID3"
+
+	// LatestVersion is the major version used for newly created tags.
+	LatestVersion byte = 4
+)
+
+const (
+	UnsynchronisationFlag byte = 1 << 7
+	ExtendedHeaderFlag    byte = 1 << 6
+	ExperimentalFlag      byte = 1 << 5
+	FooterFlag            byte = 1 << 4
+)
+
+// Header represents the 10-byte header that precedes every ID3v2 tag.
+type Header struct {
+	Version  byte
+	Revision byte
+	Flags    byte
+	Size     int
+}
+
+// hasFlag reports whether the given flag bit is set.
+func (h Header) hasFlag(flag byte) bool {
+	return h.Flags&flag != 0
+}
+
+// ParseHeader reads and validates the 10-byte ID3v2 header from reader. It
+// returns a nil header and nil error if reader is too short to hold one,
+// or if the identifier does not match "ID3".
+func ParseHeader(reader io.Reader) (*Header, error) {
+	data := make([]byte, HeaderSize)
+	n, err := io.ReadFull(reader, data)
+	if err == io.EOF || err == io.ErrUnexpectedEOF || n < HeaderSize {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if string(data[:3]) != identifier {
+		return nil, nil
+	}
+
+	if data[3] == 0xff || data[4] == 0xff {
+		return nil, errors.New("v2: invalid version byte")
+	}
+
+	size := ByteToInt(data[6:10], true)
+	if size < 0 {
+		return nil, errors.New("v2: invalid tag size")
+	}
+
+	return &Header{
+		Version:  data[3],
+		Revision: data[4],
+		Flags:    data[5],
+		Size:     size,
+	}, nil
+}
+
+// removeUnsynch reverses ID3v2.3-style tag-level unsynchronisation: every
+// 0xff byte followed by a stuffed 0x00 has that 0x00 removed.
+func removeUnsynch(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+
+	for i := 0; i < len(data); i++ {
+		out = append(out, data[i])
+		if data[i] == 0xff && i+1 < len(data) && data[i+1] == 0x00 {
+			i++
+		}
+	}
+
+	return out
+}
+
+// Bytes serializes the header, using size as the synchsafe tag size field.
+func (h Header) Bytes(size int) []byte {
+	bytes := make([]byte, HeaderSize)
+	copy(bytes, identifier)
+	bytes[3] = h.Version
+	bytes[4] = h.Revision
+	bytes[5] = h.Flags
+	copy(bytes[6:10], IntToByte(size, 4, true))
+
+	return bytes
+}
@@ -0,0 +1,40 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package v2
+
+// ByteToInt interprets the given bytes as a big-endian integer. When synch
+// is true, each byte is treated as a 7-bit synchsafe digit, as used for
+// ID3v2.4 sizes and ID3v2 tag/extended header sizes.
+func ByteToInt(bytes []byte, synch bool) int {
+	n := 0
+	for _, b := range bytes {
+		if synch {
+			n = n<<7 | int(b&0x7f)
+		} else {
+			n = n<<8 | int(b)
+		}
+	}
+
+	return n
+}
+
+// IntToByte renders n as a big-endian byte slice of the given length. When
+// synch is true, each byte holds 7 bits, as used for ID3v2.4 sizes and
+// ID3v2 tag/extended header sizes.
+func IntToByte(n int, length int, synch bool) []byte {
+	bytes := make([]byte, length)
+
+	for i := length - 1; i >= 0; i-- {
+		if synch {
+			bytes[i] = byte(n & 0x7f)
+			n >>= 7
+		} else {
+			bytes[i] = byte(n & 0xff)
+			n >>= 8
+		}
+	}
+
+	return bytes
+}
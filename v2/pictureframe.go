@@ -0,0 +1,121 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package v2
+
+// Standard APIC picture types, per the ID3v2 spec.
+const (
+	PictureTypeOther      byte = 0
+	PictureTypeFileIcon   byte = 1
+	PictureTypeFrontCover byte = 3
+	PictureTypeBackCover  byte = 4
+)
+
+// PictureFrame represents an APIC frame: image data embedded directly in
+// the tag, along with its MIME type and a short description.
+type PictureFrame struct {
+	FrameHead
+	encoding    byte
+	mimeType    string
+	pictureType byte
+	description string
+	data        []byte
+}
+
+// NewPictureFrame returns a new APIC frame holding data (already encoded
+// in mimeType, e.g. "image/jpeg"), described by description.
+func NewPictureFrame(mimeType string, pictureType byte, description string, data []byte) *PictureFrame {
+	return &PictureFrame{
+		FrameHead:   NewFrameHead(V24FrameTypeMap["APIC"], LatestVersion),
+		encoding:    EncodingUTF8,
+		mimeType:    mimeType,
+		pictureType: pictureType,
+		description: description,
+		data:        data,
+	}
+}
+
+func parsePictureFrame(head FrameHead, data []byte) Framer {
+	if len(data) == 0 {
+		return &PictureFrame{FrameHead: head}
+	}
+
+	encoding := data[0]
+	rest := data[1:]
+
+	mimeType, rest := split(EncodingISO8859_1, rest)
+
+	var pictureType byte
+	if len(rest) > 0 {
+		pictureType = rest[0]
+		rest = rest[1:]
+	}
+
+	description, picture := split(encoding, rest)
+
+	return &PictureFrame{
+		FrameHead:   head,
+		encoding:    encoding,
+		mimeType:    string(mimeType),
+		pictureType: pictureType,
+		description: decodeText(encoding, description),
+		data:        picture,
+	}
+}
+
+func (f PictureFrame) MIMEType() string {
+	return f.mimeType
+}
+
+func (f PictureFrame) PictureType() byte {
+	return f.pictureType
+}
+
+func (f PictureFrame) Description() string {
+	return f.description
+}
+
+func (f *PictureFrame) SetDescription(description string) {
+	f.description = description
+}
+
+func (f PictureFrame) Data() []byte {
+	return f.data
+}
+
+func (f *PictureFrame) SetData(mimeType string, data []byte) {
+	f.mimeType = mimeType
+	f.data = data
+}
+
+func (f PictureFrame) Size() int {
+	return FrameHeaderSize + len(f.body())
+}
+
+func (f PictureFrame) body() []byte {
+	body := []byte{f.encoding}
+	body = append(body, []byte(f.mimeType)...)
+	body = append(body, 0)
+	body = append(body, f.pictureType)
+	body = append(body, encodeText(f.encoding, f.description)...)
+
+	if f.encoding == EncodingUTF16BOM || f.encoding == EncodingUTF16BE {
+		body = append(body, 0, 0)
+	} else {
+		body = append(body, 0)
+	}
+
+	body = append(body, f.data...)
+
+	return body
+}
+
+func (f PictureFrame) Bytes() []byte {
+	body := f.body()
+	return append(f.FrameHead.Bytes(len(body)), body...)
+}
+
+func (f PictureFrame) String() string {
+	return f.description
+}
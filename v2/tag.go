@@ -0,0 +1,261 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package v2 implements the reading and writing of ID3v2.3/ID3v2.4 tags.
+package v2
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	// PaddingSize is the default amount of padding appended to a newly
+	// written tag, to leave room for small edits without rewriting audio.
+	PaddingSize = 2048
+)
+
+// Tag represents an ID3v2.3 or ID3v2.4 tag: a header plus an ordered set
+// of frames.
+type Tag struct {
+	Header
+	frames  map[string][]Framer
+	order   []string
+	padding uint
+
+	// onDiskSize is the number of bytes the tag actually occupied on
+	// disk when it was parsed, i.e. HeaderSize plus the (possibly
+	// unsynch-stuffed) body size the header declared. It is 0 for tags
+	// that were not produced by ParseTag, such as NewTag's.
+	onDiskSize int
+}
+
+// NewTag returns an empty tag targeting the given major version (3 or 4).
+func NewTag(version byte) *Tag {
+	return &Tag{
+		Header:  Header{Version: version, Revision: 0, Flags: 0},
+		frames:  make(map[string][]Framer),
+		padding: PaddingSize,
+	}
+}
+
+// ParseTag reads an ID3v2 tag from the front of reader. It returns a nil
+// tag and nil error if no ID3v2 header is present.
+func ParseTag(reader io.Reader) (*Tag, error) {
+	header, err := ParseHeader(reader)
+	if err != nil || header == nil {
+		return nil, err
+	}
+
+	// header.Size comes straight from the (untrusted) tag header, and can
+	// claim up to 256MB. Read through a LimitReader rather than
+	// preallocating a buffer of that size up front, so a crafted header
+	// on a short stream can't force a large allocation.
+	data, err := ioutil.ReadAll(io.LimitReader(reader, int64(header.Size)))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < header.Size {
+		return nil, errors.New("v2: declared tag size exceeds available data")
+	}
+
+	if header.hasFlag(UnsynchronisationFlag) {
+		data = removeUnsynch(data)
+	}
+
+	if header.hasFlag(ExtendedHeaderFlag) {
+		if len(data) < 4 {
+			return nil, nil
+		}
+		extSize := ByteToInt(data[0:4], header.Version >= 4)
+		if extSize < 0 || extSize > len(data) {
+			return nil, nil
+		}
+		data = data[extSize:]
+	}
+
+	tag := &Tag{Header: *header, frames: make(map[string][]Framer), onDiskSize: HeaderSize + header.Size}
+
+	for len(data) >= FrameHeaderSize {
+		if data[0] == 0 {
+			break
+		}
+
+		frame, size, err := parseFrame(header.Version, data)
+		if err != nil {
+			break
+		}
+
+		tag.addFrame(frame)
+		data = data[size:]
+	}
+
+	// Whatever is left of the original header.Size bytes is the padding
+	// that followed the frames on disk; preserve it so re-serializing an
+	// untouched tag reproduces the same size.
+	tag.padding = uint(len(data))
+
+	return tag, nil
+}
+
+func (t *Tag) addFrame(f Framer) {
+	id := f.Id()
+	if _, ok := t.frames[id]; !ok {
+		t.order = append(t.order, id)
+	}
+	t.frames[id] = append(t.frames[id], f)
+}
+
+// AddFrames appends one or more frames to the tag.
+func (t *Tag) AddFrames(frames ...Framer) {
+	for _, f := range frames {
+		t.addFrame(f)
+	}
+}
+
+// Frame returns the first frame with the given id, or nil.
+func (t *Tag) Frame(id string) Framer {
+	if fs := t.frames[id]; len(fs) > 0 {
+		return fs[0]
+	}
+
+	return nil
+}
+
+// Frames returns every frame with the given id.
+func (t *Tag) Frames(id string) []Framer {
+	return t.frames[id]
+}
+
+// AllFrames returns every frame in the tag, in the order they were added.
+func (t *Tag) AllFrames() []Framer {
+	var all []Framer
+	for _, id := range t.order {
+		all = append(all, t.frames[id]...)
+	}
+
+	return all
+}
+
+// DeleteFrames removes and returns every frame with the given id.
+func (t *Tag) DeleteFrames(id string) []Framer {
+	fs := t.frames[id]
+	if fs == nil {
+		return nil
+	}
+
+	delete(t.frames, id)
+	for i, existing := range t.order {
+		if existing == id {
+			t.order = append(t.order[:i], t.order[i+1:]...)
+			break
+		}
+	}
+
+	return fs
+}
+
+// Padding returns the number of zero-padding bytes appended after the
+// frames when the tag is serialized.
+func (t *Tag) Padding() uint {
+	return t.padding
+}
+
+// SetPadding sets the number of zero-padding bytes appended after the
+// frames when the tag is serialized.
+func (t *Tag) SetPadding(padding uint) {
+	t.padding = padding
+}
+
+func (t *Tag) textFrame(id string) string {
+	if f, ok := t.Frame(id).(*TextFrame); ok {
+		return f.Text()
+	}
+
+	return ""
+}
+
+func (t *Tag) setTextFrame(id, text string) {
+	if f, ok := t.Frame(id).(*TextFrame); ok {
+		f.SetText(text)
+		return
+	}
+
+	ft, ok := frameTypeMap(t.Version)[id]
+	if !ok {
+		ft = FrameType{id: id, description: id, constructor: parseTextFrame}
+	}
+	t.addFrame(NewTextFrame(ft, text))
+}
+
+func (t *Tag) Title() string        { return t.textFrame("TIT2") }
+func (t *Tag) SetTitle(text string) { t.setTextFrame("TIT2", text) }
+
+func (t *Tag) Artist() string        { return t.textFrame("TPE1") }
+func (t *Tag) SetArtist(text string) { t.setTextFrame("TPE1", text) }
+
+func (t *Tag) Album() string        { return t.textFrame("TALB") }
+func (t *Tag) SetAlbum(text string) { t.setTextFrame("TALB", text) }
+
+func (t *Tag) Year() string        { return t.textFrame("TYER") }
+func (t *Tag) SetYear(text string) { t.setTextFrame("TYER", text) }
+
+func (t *Tag) Genre() string        { return t.textFrame("TCON") }
+func (t *Tag) SetGenre(text string) { t.setTextFrame("TCON", text) }
+
+func (t *Tag) Comments() string {
+	if f, ok := t.Frame("COMM").(*UnsynchTextFrame); ok {
+		return f.Text()
+	}
+
+	return ""
+}
+
+func (t *Tag) SetComments(text string) {
+	if f, ok := t.Frame("COMM").(*UnsynchTextFrame); ok {
+		f.SetText(text)
+		return
+	}
+
+	t.addFrame(NewUnsynchTextFrame(frameTypeMap(t.Version)["COMM"], "", text))
+}
+
+// Size returns the total serialized size of the tag, including its
+// 10-byte header and any padding, as Bytes would (re)write it. This is
+// not necessarily the number of bytes the tag occupied on disk when
+// parsed; use OnDiskSize for that.
+func (t *Tag) Size() int {
+	size := HeaderSize
+	for _, f := range t.AllFrames() {
+		size += f.Size()
+	}
+
+	return size + int(t.padding)
+}
+
+// OnDiskSize returns the number of bytes the tag occupied on disk when it
+// was parsed by ParseTag, including any unsynchronisation stuffing Bytes
+// no longer reproduces. It returns 0 for tags that were not produced by
+// ParseTag, such as NewTag's.
+func (t *Tag) OnDiskSize() int {
+	return t.onDiskSize
+}
+
+// Bytes serializes the tag to its on-disk representation. It never
+// re-applies unsynchronisation stuffing, so the Unsynchronisation flag is
+// always cleared on write even if it was set on the parsed tag.
+func (t *Tag) Bytes() []byte {
+	var body []byte
+	for _, f := range t.AllFrames() {
+		body = append(body, f.Bytes()...)
+	}
+
+	body = append(body, make([]byte, t.padding)...)
+
+	header := t.Header
+	header.Flags &^= UnsynchronisationFlag
+
+	return append(header.Bytes(len(body)), body...)
+}
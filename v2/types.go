@@ -0,0 +1,70 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package v2
+
+const (
+	// FrameHeaderSize is the number of bytes in an ID3v2 frame header.
+	FrameHeaderSize = 10
+)
+
+// Framer is the interface implemented by every parsed ID3v2 frame.
+type Framer interface {
+	Id() string
+	Size() int
+	StatusFlags() byte
+	FormatFlags() byte
+	Bytes() []byte
+	String() string
+}
+
+// FrameType describes a class of frame: its id, a short description and the
+// constructor used to build a Framer from a parsed frame body.
+type FrameType struct {
+	id          string
+	description string
+	constructor func(FrameHead, []byte) Framer
+}
+
+func (ft FrameType) Id() string {
+	return ft.id
+}
+
+func (ft FrameType) Description() string {
+	return ft.description
+}
+
+// FrameHead holds the fields common to every frame: its type, the two
+// status/format flag bytes and the version of the tag it was parsed from
+// or is destined for.
+type FrameHead struct {
+	FrameType
+	version     byte
+	statusFlags byte
+	formatFlags byte
+}
+
+// NewFrameHead returns a FrameHead for ft, targeting the given tag version.
+func NewFrameHead(ft FrameType, version byte) FrameHead {
+	return FrameHead{FrameType: ft, version: version}
+}
+
+func (h FrameHead) StatusFlags() byte {
+	return h.statusFlags
+}
+
+func (h FrameHead) FormatFlags() byte {
+	return h.formatFlags
+}
+
+// Bytes serializes the 10-byte frame header for a body of the given length.
+func (h FrameHead) Bytes(bodySize int) []byte {
+	bytes := make([]byte, FrameHeaderSize)
+	copy(bytes, h.id)
+	copy(bytes[4:8], IntToByte(bodySize, 4, h.version >= 4))
+	bytes[8] = h.statusFlags
+	bytes[9] = h.formatFlags
+
+	return bytes
+}
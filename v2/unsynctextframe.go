@@ -0,0 +1,108 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package v2
+
+// UnsynchTextFrame represents frames that carry a language, a short
+// description and a body of free text, such as COMM (comments) and USLT
+// (unsynchronised lyrics).
+type UnsynchTextFrame struct {
+	FrameHead
+	encoding    byte
+	language    string
+	description string
+	text        string
+}
+
+// NewUnsynchTextFrame returns a new comment/lyrics-style frame of the given
+// type, encoded as UTF-8 with the "eng" language.
+func NewUnsynchTextFrame(ft FrameType, description, text string) *UnsynchTextFrame {
+	return &UnsynchTextFrame{
+		FrameHead:   NewFrameHead(ft, LatestVersion),
+		encoding:    EncodingUTF8,
+		language:    "eng",
+		description: description,
+		text:        text,
+	}
+}
+
+func parseUnsynchTextFrame(head FrameHead, data []byte) Framer {
+	if len(data) < 4 {
+		return &UnsynchTextFrame{FrameHead: head}
+	}
+
+	encoding := data[0]
+	language := string(data[1:4])
+
+	description, rest := split(encoding, data[4:])
+
+	return &UnsynchTextFrame{
+		FrameHead:   head,
+		encoding:    encoding,
+		language:    language,
+		description: decodeText(encoding, description),
+		text:        decodeText(encoding, rest),
+	}
+}
+
+func (f UnsynchTextFrame) Size() int {
+	return FrameHeaderSize + len(f.body())
+}
+
+func (f UnsynchTextFrame) Language() string {
+	return f.language
+}
+
+func (f UnsynchTextFrame) Description() string {
+	return f.description
+}
+
+// Encoding returns the text encoding (one of the Encoding* constants) used
+// for the frame's description and text.
+func (f UnsynchTextFrame) Encoding() byte {
+	return f.encoding
+}
+
+// SetEncoding sets the text encoding (one of the Encoding* constants) used
+// when the frame is serialized.
+func (f *UnsynchTextFrame) SetEncoding(encoding byte) {
+	f.encoding = encoding
+}
+
+func (f *UnsynchTextFrame) SetDescription(description string) {
+	f.description = description
+}
+
+func (f UnsynchTextFrame) Text() string {
+	return f.text
+}
+
+func (f *UnsynchTextFrame) SetText(text string) {
+	f.text = text
+}
+
+func (f UnsynchTextFrame) body() []byte {
+	body := []byte{f.encoding}
+	body = append(body, []byte(f.language)...)
+	body = append(body, encodeText(f.encoding, f.description)...)
+
+	if f.encoding == EncodingUTF16BOM || f.encoding == EncodingUTF16BE {
+		body = append(body, 0, 0)
+	} else {
+		body = append(body, 0)
+	}
+
+	body = append(body, encodeText(f.encoding, f.text)...)
+
+	return body
+}
+
+func (f UnsynchTextFrame) Bytes() []byte {
+	body := f.body()
+	return append(f.FrameHead.Bytes(len(body)), body...)
+}
+
+func (f UnsynchTextFrame) String() string {
+	return f.text
+}
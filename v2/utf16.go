@@ -0,0 +1,58 @@
+// Copyright 2013 Michael Yang. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package v2
+
+import (
+	"unicode/utf16"
+)
+
+// decodeUTF16 decodes data as UTF-16. If bigEndian is false, data is
+// expected to start with a byte-order mark; its absence is treated as
+// little-endian, matching common encoder behavior in the wild.
+func decodeUTF16(data []byte, bigEndian bool) string {
+	if len(data) >= 2 && !bigEndian {
+		switch {
+		case data[0] == 0xff && data[1] == 0xfe:
+			bigEndian = false
+			data = data[2:]
+		case data[0] == 0xfe && data[1] == 0xff:
+			bigEndian = true
+			data = data[2:]
+		}
+	}
+
+	n := len(data) / 2
+	units := make([]uint16, n)
+	for i := 0; i < n; i++ {
+		if bigEndian {
+			units[i] = uint16(data[2*i])<<8 | uint16(data[2*i+1])
+		} else {
+			units[i] = uint16(data[2*i+1])<<8 | uint16(data[2*i])
+		}
+	}
+
+	return string(utf16.Decode(units))
+}
+
+// encodeUTF16 encodes text as UTF-16, prefixing a little-endian byte-order
+// mark unless bigEndian is requested.
+func encodeUTF16(text string, bigEndian bool) []byte {
+	units := utf16.Encode([]rune(text))
+
+	var out []byte
+	if !bigEndian {
+		out = append(out, 0xff, 0xfe)
+	}
+
+	for _, u := range units {
+		if bigEndian {
+			out = append(out, byte(u>>8), byte(u))
+		} else {
+			out = append(out, byte(u), byte(u>>8))
+		}
+	}
+
+	return out
+}